@@ -0,0 +1,43 @@
+// Type definitions specific to Crossref
+
+package api
+
+// CrossrefResponse is returned by the Crossref REST API for a single work
+//
+// Reference: https://api.crossref.org/swagger-ui/index.html
+type CrossrefResponse struct {
+	Message CrossrefMessage `json:"message"`
+}
+
+// CrossrefMessage holds the metadata describing the work
+type CrossrefMessage struct {
+	DOI     string            `json:"DOI"`
+	Title   []string          `json:"title"`
+	Author  []CrossrefAuthor  `json:"author"`
+	Issued  CrossrefDate      `json:"issued"`
+	License []CrossrefLicense `json:"license"`
+	Link    []CrossrefLink    `json:"link"`
+}
+
+// CrossrefAuthor is a single author entry
+type CrossrefAuthor struct {
+	Given  string `json:"given"`
+	Family string `json:"family"`
+}
+
+// CrossrefDate is Crossref's "date-parts" representation of a publication date
+type CrossrefDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// CrossrefLicense is a single license entry
+type CrossrefLicense struct {
+	URL string `json:"URL"`
+}
+
+// CrossrefLink is a full-text or similarity-checking link associated with the work
+type CrossrefLink struct {
+	URL                 string `json:"URL"`
+	ContentType         string `json:"content-type"`
+	IntendedApplication string `json:"intended-application"`
+}
@@ -0,0 +1,52 @@
+// Type definitions specific to DataCite
+
+package api
+
+// DataCiteResponse is returned by the DataCite REST API for a single DOI
+//
+// Reference: https://support.datacite.org/docs/api-get-doi
+type DataCiteResponse struct {
+	Data DataCiteData `json:"data"`
+}
+
+// DataCiteData is the JSON:API resource for a DOI
+type DataCiteData struct {
+	ID         string             `json:"id"`
+	Attributes DataCiteAttributes `json:"attributes"`
+}
+
+// DataCiteAttributes holds the metadata describing the DOI
+type DataCiteAttributes struct {
+	DOI                string                      `json:"doi"`
+	URL                string                      `json:"url"`
+	ContentURL         []string                    `json:"contentUrl"`
+	Titles             []DataCiteTitle             `json:"titles"`
+	Creators           []DataCiteCreator           `json:"creators"`
+	PublicationYear    int                         `json:"publicationYear"`
+	Version            string                      `json:"version"`
+	RightsList         []DataCiteRights            `json:"rightsList"`
+	RelatedIdentifiers []DataCiteRelatedIdentifier `json:"relatedIdentifiers"`
+}
+
+// DataCiteTitle is a single title entry
+type DataCiteTitle struct {
+	Title string `json:"title"`
+}
+
+// DataCiteCreator is a single creator/author entry
+type DataCiteCreator struct {
+	Name string `json:"name"`
+}
+
+// DataCiteRights is a single license/rights statement
+type DataCiteRights struct {
+	Rights string `json:"rights"`
+}
+
+// DataCiteRelatedIdentifier describes a resource related to the DOI, which
+// may itself point at a downloadable file
+type DataCiteRelatedIdentifier struct {
+	RelatedIdentifier     string `json:"relatedIdentifier"`
+	RelatedIdentifierType string `json:"relatedIdentifierType"`
+	RelationType          string `json:"relationType"`
+}
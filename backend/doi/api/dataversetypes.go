@@ -2,6 +2,8 @@
 
 package api
 
+import "encoding/json"
+
 // DataverseDatasetResponse is returned by the Dataverse API
 type DataverseDatasetResponse struct {
 	Status string           `json:"status"`
@@ -15,8 +17,48 @@ type DataverseDataset struct {
 
 // Representation of a dataset version
 type DataverseDatasetVersion struct {
-	LastUpdateTime string          `json:"lastUpdateTime"`
-	Files          []DataverseFile `json:"files"`
+	LastUpdateTime     string                  `json:"lastUpdateTime"`
+	VersionNumber      int                     `json:"versionNumber"`
+	VersionMinorNumber int                     `json:"versionMinorNumber"`
+	License            DataverseLicense        `json:"license"`
+	MetadataBlocks     DataverseMetadataBlocks `json:"metadataBlocks"`
+	Files              []DataverseFile         `json:"files"`
+}
+
+// DataverseLicense describes the license a dataset is published under
+type DataverseLicense struct {
+	Name string `json:"name"`
+	URI  string `json:"uri"`
+}
+
+// DataverseMetadataBlocks holds a dataset version's metadata blocks
+type DataverseMetadataBlocks struct {
+	Citation DataverseCitationBlock `json:"citation"`
+}
+
+// DataverseCitationBlock is the "citation" metadata block, the one
+// containing the fields needed to cite the dataset
+type DataverseCitationBlock struct {
+	Fields []DataverseCitationField `json:"fields"`
+}
+
+// DataverseCitationField is a single field of the citation metadata block.
+// Value is left as raw JSON since its shape depends on TypeName (a plain
+// string for "title", a list of compound author entries for "author", etc).
+type DataverseCitationField struct {
+	TypeName string          `json:"typeName"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// DataverseCitationAuthor is a single entry of the "author" compound field
+type DataverseCitationAuthor struct {
+	AuthorName DataverseCitationFieldValue `json:"authorName"`
+}
+
+// DataverseCitationFieldValue is the value of a single sub-field of a
+// compound citation field
+type DataverseCitationFieldValue struct {
+	Value string `json:"value"`
 }
 
 // Representation of a file found in a dataset
@@ -4,7 +4,50 @@ package api
 
 // InvenioRecordResponse si the representation of a record stored in InvenioDRM
 type InvenioRecordResponse struct {
-	Links InvenioRecordResponseLinks `json:"links"`
+	ID       string                        `json:"id"`
+	Links    InvenioRecordResponseLinks    `json:"links"`
+	Pids     InvenioRecordResponsePids     `json:"pids"`
+	Metadata InvenioRecordResponseMetadata `json:"metadata"`
+}
+
+// InvenioRecordResponseMetadata holds the descriptive metadata of a record
+type InvenioRecordResponseMetadata struct {
+	Title              string                                   `json:"title"`
+	Creators           []InvenioRecordResponseCreator           `json:"creators"`
+	PublicationDate    string                                   `json:"publication_date"`
+	Version            string                                   `json:"version"`
+	Rights             []InvenioRecordResponseRight             `json:"rights"`
+	RelatedIdentifiers []InvenioRecordResponseRelatedIdentifier `json:"related_identifiers"`
+}
+
+// InvenioRecordResponseCreator is a single creator/author entry
+type InvenioRecordResponseCreator struct {
+	PersonOrOrg InvenioRecordResponsePersonOrOrg `json:"person_or_org"`
+}
+
+// InvenioRecordResponsePersonOrOrg names a creator/author
+type InvenioRecordResponsePersonOrOrg struct {
+	Name string `json:"name"`
+}
+
+// InvenioRecordResponseRight is a single license/rights statement
+type InvenioRecordResponseRight struct {
+	ID string `json:"id"`
+}
+
+// InvenioRecordResponseRelatedIdentifier is a single related identifier entry
+type InvenioRecordResponseRelatedIdentifier struct {
+	Identifier string `json:"identifier"`
+}
+
+// InvenioRecordResponsePids represents a record's persistent identifiers
+type InvenioRecordResponsePids struct {
+	DOI InvenioRecordResponsePid `json:"doi"`
+}
+
+// InvenioRecordResponsePid is a single persistent identifier
+type InvenioRecordResponsePid struct {
+	Identifier string `json:"identifier"`
 }
 
 // InvenioRecordResponseLinks represents of a record's links
@@ -0,0 +1,30 @@
+// Type definitions for the Signposting `application/linkset+json` format
+//
+// Reference: https://signposting.org/conventions/
+
+package api
+
+// LinksetDocument is the top level `application/linkset+json` document
+type LinksetDocument struct {
+	Linkset []Linkset `json:"linkset"`
+}
+
+// Linkset is a single set of typed links describing one anchor resource
+type Linkset struct {
+	Anchor      string        `json:"anchor"`
+	Item        []LinksetLink `json:"item"`
+	DescribedBy []LinksetLink `json:"describedby"`
+	CiteAs      []LinksetLink `json:"cite-as"`
+	Collection  []LinksetLink `json:"collection"`
+	License     []LinksetLink `json:"license"`
+	Author      []LinksetLink `json:"author"`
+	Type        []LinksetLink `json:"type"`
+}
+
+// LinksetLink is a single typed link target
+type LinksetLink struct {
+	Href     string `json:"href"`
+	Type     string `json:"type"`
+	Length   int64  `json:"length"`
+	Hreflang string `json:"hreflang"`
+}
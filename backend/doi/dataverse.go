@@ -4,7 +4,9 @@ package doi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
@@ -33,6 +35,77 @@ func resolveDataverseEndpoint(resolvedURL *url.URL) (provider Provider, endpoint
 	return Dataverse, endpointURL, nil
 }
 
+// fetchDataverseMetadata fetches and normalizes the metadata of a dataset
+// hosted on a Dataverse installation
+func (f *Fs) fetchDataverseMetadata(ctx context.Context) (*Metadata, error) {
+	filesURL := f.endpoint
+	var result api.DataverseDatasetResponse
+	opts := rest.Opts{
+		Method:     "GET",
+		Path:       strings.TrimLeft(filesURL.EscapedPath(), "/"),
+		Parameters: filesURL.Query(),
+	}
+	var res *http.Response
+	err := f.pacer.Call(func() (bool, error) {
+		var callErr error
+		res, callErr = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, callErr)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch metadata: %w", err)
+	}
+
+	files, err := f.metadataFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version := result.Data.LatestVersion
+	return &Metadata{
+		Title:           dataverseCitationString(version.MetadataBlocks.Citation.Fields, "title"),
+		Authors:         dataverseCitationAuthors(version.MetadataBlocks.Citation.Fields),
+		PublicationDate: dataverseCitationString(version.MetadataBlocks.Citation.Fields, "distributionDate"),
+		Version:         fmt.Sprintf("%d.%d", version.VersionNumber, version.VersionMinorNumber),
+		License:         version.License.Name,
+		Files:           files,
+	}, nil
+}
+
+// dataverseCitationString returns the plain string value of the citation
+// field with the given typeName, or "" if not present
+func dataverseCitationString(fields []api.DataverseCitationField, typeName string) string {
+	for _, field := range fields {
+		if field.TypeName != typeName {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(field.Value, &value); err == nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// dataverseCitationAuthors extracts the author names from the compound
+// "author" citation field
+func dataverseCitationAuthors(fields []api.DataverseCitationField) (authors []string) {
+	for _, field := range fields {
+		if field.TypeName != "author" {
+			continue
+		}
+		var entries []api.DataverseCitationAuthor
+		if err := json.Unmarshal(field.Value, &entries); err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.AuthorName.Value != "" {
+				authors = append(authors, entry.AuthorName.Value)
+			}
+		}
+	}
+	return authors
+}
+
 // Implements Fs.List() for Dataverse installations
 func (f *Fs) listDataverse(ctx context.Context, dir string) (entries fs.DirEntries, err error) {
 	fileEntries, err := f.listDataverseDoiFiles(ctx)
@@ -70,7 +143,7 @@ func (f *Fs) listDataverse(ctx context.Context, dir string) (entries fs.DirEntri
 
 // List the files contained in the DOI
 func (f *Fs) listDataverseDoiFiles(ctx context.Context) (entries []*Object, err error) {
-	// Use the cache if populated
+	// Use the in-process cache if populated
 	cachedEntries, found := f.cache.GetMaybe("files")
 	if found {
 		parsedEntries, ok := cachedEntries.([]Object)
@@ -83,6 +156,23 @@ func (f *Fs) listDataverseDoiFiles(ctx context.Context) (entries []*Object, err
 		}
 	}
 
+	// Fall back to the on-disk cache, which survives across invocations.
+	// Dataverse doesn't expose LatestVersion.LastUpdateTime anywhere
+	// cheaper than the dataset endpoint this function itself calls below
+	// (Files live inside that same response), so there's no version to
+	// check the cache against without doing the fetch the cache exists to
+	// avoid; freshness here is TTL-only.
+	var diskCachedEntries []diskCacheObject
+	if f.diskCache.get(diskCacheListings, f.diskCacheKey(), "", &diskCachedEntries) {
+		entries = fromDiskCacheObjects(f, diskCachedEntries)
+		cacheEntries := []Object{}
+		for _, entry := range entries {
+			cacheEntries = append(cacheEntries, *entry)
+		}
+		f.cache.Put("files", cacheEntries)
+		return entries, nil
+	}
+
 	filesURL := f.endpoint
 	fs.Logf(f, "filesURL = '%s'", filesURL.String())
 	var result api.DataverseDatasetResponse
@@ -92,9 +182,12 @@ func (f *Fs) listDataverseDoiFiles(ctx context.Context) (entries []*Object, err
 		Parameters: filesURL.Query(),
 	}
 	fs.Logf(f, "filesAPIPath = '%s?%s'", opts.Path, opts.Parameters.Encode())
-	res, err := f.srv.CallJSON(ctx, &opts, nil, &result)
+	var res *http.Response
+	err = f.pacer.Call(func() (bool, error) {
+		res, err = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
-		fs.Logf(f, "%s", res.Status)
 		return nil, fmt.Errorf("readDir failed: %w", err)
 	}
 	modTime, modTimeErr := time.Parse(time.RFC3339, result.Data.LatestVersion.LastUpdateTime)
@@ -123,11 +216,12 @@ func (f *Fs) listDataverseDoiFiles(ctx context.Context) (entries []*Object, err
 		}
 		entries = append(entries, entry)
 	}
-	// Populate the cache
+	// Populate the in-process and on-disk caches
 	cacheEntries := []Object{}
 	for _, entry := range entries {
 		cacheEntries = append(cacheEntries, *entry)
 	}
 	f.cache.Put("files", cacheEntries)
+	f.diskCache.put(diskCacheListings, f.diskCacheKey(), result.Data.LatestVersion.LastUpdateTime, toDiskCacheObjects(entries))
 	return entries, nil
 }
@@ -0,0 +1,192 @@
+// A small on-disk cache for listings, metadata and linkset responses,
+// shared across providers and persisted across rclone invocations. This
+// complements the in-process `f.cache` (lib/cache.Cache), which only lives
+// for the duration of a single command.
+
+package doi
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/config"
+)
+
+// diskCacheNamespaces are the on-disk cache namespaces used by this backend
+const (
+	diskCacheListings = "listings"
+	diskCacheMetadata = "metadata"
+	diskCacheLinkset  = "linkset"
+)
+
+// diskCacheEntry is the envelope persisted for each cached value
+type diskCacheEntry struct {
+	Version  string          `json:"version"`  // the DOI version (e.g. LastUpdateTime) the entry was captured for
+	StoredAt int64           `json:"storedAt"` // unix time the entry was written
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// diskCache persists namespaced JSON entries to disk, keyed by the resolved
+// DOI and endpoint URL. A maxAge of 0 disables the cache; a negative
+// maxAge means entries never expire on their own (they are only replaced
+// when the data is re-fetched).
+type diskCache struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// newDiskCache builds the diskCache described by opt, defaulting the cache
+// directory to a "doi" subdirectory of rclone's own cache directory
+func newDiskCache(opt *Options) *diskCache {
+	if opt.CacheDisabled {
+		return &diskCache{}
+	}
+	dir := opt.CacheDir
+	if dir == "" {
+		dir = filepath.Join(config.GetCacheDir(), "doi")
+	}
+	return &diskCache{dir: dir, maxAge: time.Duration(opt.CacheMaxAge)}
+}
+
+// enabled reports whether the cache is usable at all
+func (c *diskCache) enabled() bool {
+	return c.dir != "" && c.maxAge != 0
+}
+
+// path returns the on-disk path an entry for key is stored at within namespace
+func (c *diskCache) path(namespace, key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, namespace, hex.EncodeToString(sum[:])+".json")
+}
+
+// get reads the cached value for key in namespace into out, reporting
+// whether a usable entry was found. An entry is usable if it isn't
+// expired (governed by maxAge) and, when expectedVersion is non-empty,
+// was stored for that same DOI version; a stored version that differs
+// from expectedVersion is treated as a miss regardless of age. Pass ""
+// for expectedVersion when the caller has no cheap way to learn the
+// current version without doing the same work the cache is meant to
+// avoid; freshness then falls back to being governed purely by maxAge.
+func (c *diskCache) get(namespace, key, expectedVersion string, out interface{}) bool {
+	if !c.enabled() {
+		return false
+	}
+	data, err := os.ReadFile(c.path(namespace, key))
+	if err != nil {
+		return false
+	}
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return false
+	}
+	if expectedVersion != "" && entry.Version != "" && entry.Version != expectedVersion {
+		return false
+	}
+	if c.maxAge > 0 && time.Since(time.Unix(entry.StoredAt, 0)) > c.maxAge {
+		return false
+	}
+	return json.Unmarshal(entry.Payload, out) == nil
+}
+
+// put persists value for key in namespace, tagged with the DOI version it
+// was captured for (e.g. a dataset's LastUpdateTime), logging when that
+// version differs from whatever was cached before
+func (c *diskCache) put(namespace, key, version string, value interface{}) {
+	if !c.enabled() {
+		return
+	}
+	path := c.path(namespace, key)
+	if previous, err := os.ReadFile(path); err == nil {
+		var previousEntry diskCacheEntry
+		if json.Unmarshal(previous, &previousEntry) == nil && previousEntry.Version != "" && previousEntry.Version != version {
+			fs.Logf(nil, "doi: cached DOI version changed (%q -> %q), refreshing %q cache", previousEntry.Version, version, namespace)
+		}
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(diskCacheEntry{Version: version, StoredAt: time.Now().Unix(), Payload: payload})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		fs.Logf(nil, "doi: could not create cache directory: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		fs.Logf(nil, "doi: could not write cache entry: %v", err)
+	}
+}
+
+// diskCacheObject is the JSON-serializable form of Object used when
+// persisting file listings to the on-disk cache. Object's fields are all
+// unexported, so json.Marshal would otherwise silently produce "{}" for
+// each entry; fs is dropped since it isn't meaningful across invocations
+// and is reattached by fromDiskCacheObjects on read.
+type diskCacheObject struct {
+	Remote      string    `json:"remote"`
+	ContentURL  string    `json:"contentURL"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+	ContentType string    `json:"contentType"`
+	MD5         string    `json:"md5"`
+}
+
+// toDiskCacheObjects converts entries into their on-disk-cacheable form
+func toDiskCacheObjects(entries []*Object) []diskCacheObject {
+	cached := make([]diskCacheObject, 0, len(entries))
+	for _, entry := range entries {
+		cached = append(cached, diskCacheObject{
+			Remote:      entry.remote,
+			ContentURL:  entry.contentURL,
+			Size:        entry.size,
+			ModTime:     entry.modTime,
+			ContentType: entry.contentType,
+			MD5:         entry.md5,
+		})
+	}
+	return cached
+}
+
+// fromDiskCacheObjects rebuilds Objects belonging to f from their
+// on-disk-cached form
+func fromDiskCacheObjects(f *Fs, cached []diskCacheObject) []*Object {
+	entries := make([]*Object, 0, len(cached))
+	for _, entry := range cached {
+		entries = append(entries, &Object{
+			fs:          f,
+			remote:      entry.Remote,
+			contentURL:  entry.ContentURL,
+			size:        entry.Size,
+			modTime:     entry.ModTime,
+			contentType: entry.ContentType,
+			md5:         entry.MD5,
+		})
+	}
+	return entries
+}
+
+// diskCacheKey returns the key used to namespace f's on-disk cache entries:
+// the resolved DOI plus the endpoint URL it resolved to
+func (f *Fs) diskCacheKey() string {
+	return f.doi + "#" + f.endpointURL
+}
+
+// purge removes every cached entry for key, across all namespaces
+func (c *diskCache) purge(key string) error {
+	if c.dir == "" {
+		return nil
+	}
+	for _, namespace := range []string{diskCacheListings, diskCacheMetadata, diskCacheLinkset} {
+		if err := os.Remove(c.path(namespace, key)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package doi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCacheObjectsRoundTrip(t *testing.T) {
+	f := &Fs{name: "remote", doi: "10.5281/zenodo.1234", endpointURL: "https://zenodo.org/api/records/1234"}
+	entries := []*Object{
+		{fs: f, remote: "a.txt", contentURL: "https://example.com/a.txt", size: 10, modTime: timeUnset, contentType: "text/plain", md5: "deadbeef"},
+	}
+
+	// Object's fields are all unexported, so this is the part json.Marshal
+	// would otherwise silently turn into "{}"
+	data, err := json.Marshal(toDiskCacheObjects(entries))
+	assert.NoError(t, err)
+
+	var decoded []diskCacheObject
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+
+	roundTripped := fromDiskCacheObjects(f, decoded)
+	assert.Len(t, roundTripped, 1)
+	assert.Equal(t, entries[0].remote, roundTripped[0].remote)
+	assert.Equal(t, entries[0].contentURL, roundTripped[0].contentURL)
+	assert.Equal(t, entries[0].size, roundTripped[0].size)
+	assert.Equal(t, entries[0].contentType, roundTripped[0].contentType)
+	assert.Equal(t, entries[0].md5, roundTripped[0].md5)
+	assert.Equal(t, f, roundTripped[0].fs)
+}
+
+func TestDiskCacheGetPut(t *testing.T) {
+	c := &diskCache{dir: t.TempDir(), maxAge: time.Hour}
+
+	cached := []diskCacheObject{{Remote: "a.txt", Size: 10, MD5: "deadbeef"}}
+	c.put(diskCacheListings, "key", "v1", cached)
+
+	var got []diskCacheObject
+	assert.True(t, c.get(diskCacheListings, "key", "", &got))
+	assert.Equal(t, cached, got)
+}
+
+func TestDiskCacheGetVersionMismatch(t *testing.T) {
+	c := &diskCache{dir: t.TempDir(), maxAge: time.Hour}
+
+	cached := []diskCacheObject{{Remote: "a.txt", Size: 10, MD5: "deadbeef"}}
+	c.put(diskCacheListings, "key", "v1", cached)
+
+	// A caller that knows the DOI has moved on to a new version should
+	// get a miss even though the entry is well within its TTL
+	var got []diskCacheObject
+	assert.False(t, c.get(diskCacheListings, "key", "v2", &got))
+
+	// ...but one that doesn't know the current version, or confirms it
+	// hasn't changed, still gets the cached value
+	assert.True(t, c.get(diskCacheListings, "key", "", &got))
+	assert.Equal(t, cached, got)
+	assert.True(t, c.get(diskCacheListings, "key", "v1", &got))
+	assert.Equal(t, cached, got)
+}
+
+func TestDiskCacheGetExpired(t *testing.T) {
+	c := &diskCache{dir: t.TempDir(), maxAge: time.Hour}
+
+	// Write an entry directly, stamped as if it were stored two hours ago,
+	// since put() always stamps with the current time
+	payload, err := json.Marshal([]diskCacheObject{{Remote: "a.txt"}})
+	assert.NoError(t, err)
+	entry, err := json.Marshal(diskCacheEntry{
+		Version:  "v1",
+		StoredAt: time.Now().Add(-2 * time.Hour).Unix(),
+		Payload:  payload,
+	})
+	assert.NoError(t, err)
+	path := c.path(diskCacheListings, "key")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, entry, 0600))
+
+	var got []diskCacheObject
+	assert.False(t, c.get(diskCacheListings, "key", "", &got))
+}
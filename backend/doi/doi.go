@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rclone/rclone/backend/doi/api"
@@ -20,6 +21,8 @@ import (
 	"github.com/rclone/rclone/fs/fshttp"
 	"github.com/rclone/rclone/fs/hash"
 	"github.com/rclone/rclone/lib/cache"
+	"github.com/rclone/rclone/lib/fserrors"
+	"github.com/rclone/rclone/lib/pacer"
 	"github.com/rclone/rclone/lib/rest"
 )
 
@@ -54,9 +57,63 @@ The DOI provider can be set when rclone does not automatically recognize a suppo
 			}, {
 				Value: string(Dataverse),
 				Help:  "Dataverse",
+			}, {
+				Value: string(Invenio),
+				Help:  "InvenioRDM",
+			}, {
+				Value: string(DataCite),
+				Help:  "DataCite",
+			}, {
+				Value: string(Crossref),
+				Help:  "Crossref",
+			}, {
+				Value: string(Signposting),
+				Help:  "Signposting (generic, provider-agnostic)",
 			}},
 			Required: false,
 			Advanced: true,
+		}, {
+			Name: "deposit_token",
+			Help: `API token used to authenticate deposit/upload requests.
+
+When set, this backend can create draft records and upload files to
+providers that expose a deposit API (Zenodo, InvenioRDM). Anonymous reads
+keep working exactly as before when this is not set.`,
+			Required:   false,
+			Advanced:   true,
+			IsPassword: true,
+		}, {
+			Name: "cache_dir",
+			Help: `Directory to persist the listings/metadata/linkset cache in.
+
+Defaults to a "doi" subdirectory of rclone's cache directory (see
+--cache-dir). The cache is shared across all providers; its entries are
+keyed by the resolved DOI and endpoint URL, and kept in separate
+listings, metadata and linkset namespaces.`,
+			Default:  "",
+			Required: false,
+			Advanced: true,
+		}, {
+			Name: "cache_max_age",
+			Help: `How long a cached listing/metadata/linkset entry stays valid.
+
+Set to 0 to disable caching outright, or to a negative value to cache
+entries forever (until overwritten by a fresh fetch). Providers that
+expose a cheap way to learn the current DOI version without fetching the
+full listing (currently Zenodo) invalidate a cached entry as soon as the
+version changes, regardless of age. Other providers (currently Dataverse)
+have no such cheap check available, so their cache is TTL-only: a change
+to the remote dataset may not be picked up until the cached entry
+expires.`,
+			Default:  fs.Duration(24 * time.Hour),
+			Required: false,
+			Advanced: true,
+		}, {
+			Name:     "cache_disabled",
+			Help:     `Disable the on-disk cache outright, regardless of cache_max_age.`,
+			Default:  false,
+			Required: false,
+			Advanced: true,
 		}},
 	}
 	fs.Register(fsi)
@@ -70,12 +127,24 @@ var (
 	Zenodo Provider = "zenodo"
 	// Dataverse, see https://dataverse.harvard.edu
 	Dataverse Provider = "dataverse"
+	// Invenio, see https://inveniosoftware.org
+	Invenio Provider = "invenio"
+	// DataCite, see https://datacite.org
+	DataCite Provider = "datacite"
+	// Crossref, see https://www.crossref.org
+	Crossref Provider = "crossref"
+	// Signposting, a provider-agnostic convention, see https://signposting.org
+	Signposting Provider = "signposting"
 )
 
 // Options defines the configuration for this backend
 type Options struct {
-	Doi      string `config:"doi"`      // The DOI, a digital identifier of an object, usually a dataset
-	Provider string `config:"provider"` // The DOI provider
+	Doi           string      `config:"doi"`            // The DOI, a digital identifier of an object, usually a dataset
+	Provider      string      `config:"provider"`       // The DOI provider
+	DepositToken  string      `config:"deposit_token"`  // API token used to authenticate deposit/upload requests
+	CacheDir      string      `config:"cache_dir"`      // Directory used to persist the listings/metadata/linkset cache
+	CacheMaxAge   fs.Duration `config:"cache_max_age"`  // How long a cached entry stays valid; 0 disables caching, <0 means forever
+	CacheDisabled bool        `config:"cache_disabled"` // Disables the on-disk cache outright, regardless of cache_max_age
 }
 
 // Fs stores the interface to the remote HTTP files
@@ -90,9 +159,12 @@ type Fs struct {
 	endpoint    *url.URL       // the main API endpoint for this remote
 	endpointURL string         // endpoint as a string
 	srv         *rest.Client   // the connection to the server
-	// TODO: add a pacer (from fs) for HTTP requests
+	pacer       *fs.Pacer      // pacer for API calls, to deal with rate limiting
+
+	cache     *cache.Cache // an in-process cache for the remote metadata, cleared every run
+	diskCache *diskCache   // a persistent, on-disk cache for listings/metadata/linkset responses
 
-	cache *cache.Cache // a cache for the remote metadata
+	draftMu sync.Mutex // serializes draft record creation across concurrent Put calls
 }
 
 // Object is a remote object that has been stat'd (so it exists, but is not necessarily open for reading)
@@ -121,6 +193,29 @@ func statusError(res *http.Response, err error) error {
 	return nil
 }
 
+// newPacer creates a pacer configured with an exponential backoff, so that
+// providers enforcing aggressive rate limits (e.g. Zenodo's HTTP 429s) can
+// be used reliably
+func newPacer(ctx context.Context) *fs.Pacer {
+	return fs.NewPacer(ctx, pacer.NewDefault(
+		pacer.MinSleep(100*time.Millisecond),
+		pacer.MaxSleep(2*time.Second),
+		pacer.DecayConstant(2),
+	))
+}
+
+// shouldRetry returns whether res/err deserve to be retried, pacing callers
+// through providers that throttle aggressively
+func shouldRetry(res *http.Response, err error) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		return true, err
+	}
+	return fserrors.ShouldRetry(err), err
+}
+
 // Parse the input string as a DOI
 // Examples:
 // 10.1000/182 -> 10.1000/182
@@ -142,7 +237,7 @@ func parseDoi(doi string) string {
 
 // Resolve a DOI to a URL
 // Reference: https://www.doi.org/the-identifier/resources/factsheets/doi-resolution-documentation
-func resolveDoiURL(ctx context.Context, client *http.Client, opt *Options) (doiURL *url.URL, err error) {
+func resolveDoiURL(ctx context.Context, pacer *fs.Pacer, client *http.Client, opt *Options) (doiURL *url.URL, err error) {
 	doi := parseDoi(opt.Doi)
 	doiRestClient := rest.NewClient(client).SetRoot(doiResolverApiURL)
 	params := url.Values{}
@@ -153,7 +248,11 @@ func resolveDoiURL(ctx context.Context, client *http.Client, opt *Options) (doiU
 		Parameters: params,
 	}
 	var result api.DoiResolverResponse
-	_, err = doiRestClient.CallJSON(ctx, &opts, nil, &result)
+	var res *http.Response
+	err = pacer.Call(func() (bool, error) {
+		res, err = doiRestClient.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -179,8 +278,8 @@ func resolveDoiURL(ctx context.Context, client *http.Client, opt *Options) (doiU
 }
 
 // Resolve the passed configuration into a provider and enpoint
-func resolveEndpoint(ctx context.Context, client *http.Client, opt *Options) (provider Provider, endpoint *url.URL, err error) {
-	resolvedURL, err := resolveDoiURL(ctx, client, opt)
+func resolveEndpoint(ctx context.Context, pacer *fs.Pacer, client *http.Client, opt *Options) (provider Provider, endpoint *url.URL, err error) {
+	resolvedURL, err := resolveDoiURL(ctx, pacer, client, opt)
 	if err != nil {
 		return "", nil, err
 	}
@@ -192,17 +291,69 @@ func resolveEndpoint(ctx context.Context, client *http.Client, opt *Options) (pr
 		return resolveDataverseEndpoint(resolvedURL)
 	}
 	if hostname == "zenodo.org" || strings.HasSuffix(hostname, ".zenodo.org") || opt.Provider == string(Zenodo) {
-		return resolveZenodoEndpoint(ctx, client, resolvedURL, opt.Doi)
+		return resolveZenodoEndpoint(ctx, pacer, client, resolvedURL, opt.Doi)
+	}
+
+	// Signposting is a cross-publisher convention (DSpace, Dataverse,
+	// InvenioRDM, Eprints, many publishers). Try it once the hostname
+	// didn't match one of the providers above with a native, richer
+	// resolution path (full metadata, checksums, deposit support).
+	if opt.Provider == "" || opt.Provider == string(Signposting) {
+		provider, endpoint, signpostingErr := resolveSignpostingEndpoint(ctx, pacer, client, resolvedURL)
+		if signpostingErr == nil {
+			return provider, endpoint, nil
+		}
+		fs.Logf(nil, "could not resolve via Signposting: %s", signpostingErr.Error())
+		if opt.Provider == string(Signposting) {
+			return "", nil, signpostingErr
+		}
 	}
 
+	// The resolved URL didn't match a known hostname: it might still be a
+	// self-hosted InvenioRDM installation, which can't be recognised by
+	// hostname alone. Try it before giving up.
+	if opt.Provider == string(Invenio) || opt.Provider == "" {
+		provider, endpoint, invenioErr := resolveInvenioEndpoint(ctx, pacer, client, resolvedURL)
+		if invenioErr == nil {
+			return provider, endpoint, nil
+		}
+		fs.Logf(nil, "could not resolve as InvenioRDM: %s", invenioErr.Error())
+		if opt.Provider == string(Invenio) {
+			return "", nil, invenioErr
+		}
+	}
+
+	// Finally, fall back to the DataCite and Crossref metadata APIs: the DOI
+	// prefix is always registered with one of the two, even when the handle
+	// resolves to a publisher landing page we can't otherwise navigate.
+	if opt.Provider == string(Crossref) {
+		return resolveCrossrefEndpoint(ctx, pacer, client, opt.Doi)
+	}
+	if opt.Provider == string(DataCite) || opt.Provider == "" {
+		provider, endpoint, dataciteErr := resolveDataCiteEndpoint(ctx, pacer, client, opt.Doi)
+		if dataciteErr == nil {
+			return provider, endpoint, nil
+		}
+		fs.Logf(nil, "could not resolve as DataCite: %s", dataciteErr.Error())
+		if opt.Provider == string(DataCite) {
+			return "", nil, dataciteErr
+		}
+	}
+	provider, endpoint, crossrefErr := resolveCrossrefEndpoint(ctx, pacer, client, opt.Doi)
+	if crossrefErr == nil {
+		return provider, endpoint, nil
+	}
+	fs.Logf(nil, "could not resolve as Crossref: %s", crossrefErr.Error())
+
 	return "", nil, fmt.Errorf("provider '%s' is not supported", resolvedURL.Hostname())
 }
 
 // Make the http connection from the passed options
 func (f *Fs) httpConnection(ctx context.Context, opt *Options) (isFile bool, err error) {
 	client := fshttp.NewClient(ctx)
+	pacer := newPacer(ctx)
 
-	provider, endpoint, err := resolveEndpoint(ctx, client, opt)
+	provider, endpoint, err := resolveEndpoint(ctx, pacer, client, opt)
 	if err != nil {
 		return false, err
 	}
@@ -213,6 +364,8 @@ func (f *Fs) httpConnection(ctx context.Context, opt *Options) (isFile bool, err
 	// Update f with the new parameters
 	f.srv = rest.NewClient(client).SetRoot(endpoint.ResolveReference(&url.URL{Path: "/"}).String())
 	f.cache = cache.New()
+	f.diskCache = newDiskCache(opt)
+	f.pacer = pacer
 	f.endpoint = endpoint
 	f.endpointURL = endpoint.String()
 	f.doi = parseDoi(opt.Doi) // TODO: avoid calling parseDoi() again here
@@ -231,7 +384,7 @@ func (f *Fs) httpConnection(ctx context.Context, opt *Options) (isFile bool, err
 				break
 			}
 		}
-	case Zenodo:
+	case Zenodo, Invenio, DataCite, Crossref, Signposting:
 		isFile = f.root != ""
 	}
 
@@ -316,12 +469,23 @@ func (f *Fs) Hashes() hash.Set {
 
 // Mkdir makes the root directory of the Fs object
 func (f *Fs) Mkdir(ctx context.Context, dir string) error {
-	return errorReadOnly
+	if !f.depositEnabled() {
+		return errorReadOnly
+	}
+	_, err := f.openDraft(ctx)
+	return err
 }
 
 // Remove a remote http file object
 func (o *Object) Remove(ctx context.Context) error {
-	return errorReadOnly
+	if !o.fs.depositEnabled() {
+		return errorReadOnly
+	}
+	draft, err := o.fs.openDraft(ctx)
+	if err != nil {
+		return err
+	}
+	return o.fs.removeDraftFile(ctx, draft, o.remote)
 }
 
 // Rmdir removes the root directory of the Fs object
@@ -341,6 +505,14 @@ func (f *Fs) NewObject(ctx context.Context, remote string) (fs.Object, error) {
 		entries, err = f.listDataverseDoiFiles(ctx)
 	case Zenodo:
 		entries, err = f.listZenodoDoiFiles(ctx)
+	case Invenio:
+		entries, err = f.listInvevioDoiFiles(ctx)
+	case DataCite:
+		entries, err = f.listDataCiteDoiFiles(ctx)
+	case Crossref:
+		entries, err = f.listCrossrefDoiFiles(ctx)
+	case Signposting:
+		entries, err = f.listSignpostingDoiFiles(ctx)
 	default:
 		err = fmt.Errorf("provider type '%s' not supported", f.provider)
 	}
@@ -373,6 +545,14 @@ func (f *Fs) List(ctx context.Context, dir string) (entries fs.DirEntries, err e
 		return f.listDataverse(ctx, dir)
 	case Zenodo:
 		return f.listZenodo(ctx, dir)
+	case Invenio:
+		return f.listInvenio(ctx, dir)
+	case DataCite:
+		return f.listDataCite(ctx, dir)
+	case Crossref:
+		return f.listCrossref(ctx, dir)
+	case Signposting:
+		return f.listSignposting(ctx, dir)
 	default:
 		return nil, fmt.Errorf("provider type '%s' not supported", f.provider)
 	}
@@ -384,12 +564,31 @@ func (f *Fs) List(ctx context.Context, dir string) (entries fs.DirEntries, err e
 // will return the object and the error, otherwise will return
 // nil and the error
 func (f *Fs) Put(ctx context.Context, in io.Reader, src fs.ObjectInfo, options ...fs.OpenOption) (fs.Object, error) {
-	return nil, errorReadOnly
+	if !f.depositEnabled() {
+		return nil, errorReadOnly
+	}
+	draft, err := f.openDraft(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remote := src.Remote()
+	if err := f.uploadDraftFile(ctx, draft, remote, in); err != nil {
+		return nil, err
+	}
+	return &Object{
+		fs:      f,
+		remote:  remote,
+		size:    src.Size(),
+		modTime: src.ModTime(ctx),
+	}, nil
 }
 
 // PutStream uploads to the remote path with the modTime given of indeterminate size
 func (f *Fs) PutStream(ctx context.Context, in io.Reader, src fs.ObjectInfo, options ...fs.OpenOption) (fs.Object, error) {
-	return nil, errorReadOnly
+	if !f.depositEnabled() {
+		return nil, errorReadOnly
+	}
+	return f.Put(ctx, in, src, options...)
 }
 
 // Fs is the filesystem this remote http file object is located within
@@ -453,20 +652,38 @@ func (o *Object) Open(ctx context.Context, options ...fs.OpenOption) (in io.Read
 		k, v := opt.Header()
 		fs.Logf(o, "header '%s' = '%s'", k, v)
 	}
-	res, err := o.fs.srv.Call(ctx, &opts)
+	var res *http.Response
+	err = o.fs.pacer.Call(func() (bool, error) {
+		res, err = o.fs.srv.Call(ctx, &opts)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
-		fs.Logf(o, "Open failed: '%s'", res.Status)
 		fs.Logf(o, "Open failed: '%s'", err.Error())
 		return nil, fmt.Errorf("Open failed: %w", err)
 	}
 	fs.Logf(o, "Open response: '%s'", res.Status)
 	fs.Logf(o, "Open response: '%v'", res.Header)
-	return res.Body, nil
+	if isPartialRead(options) {
+		return res.Body, nil
+	}
+	return newVerifiedReadCloser(res.Body, o.md5, o.size), nil
 }
 
 // Update in to the object with the modTime given of the given size
 func (o *Object) Update(ctx context.Context, in io.Reader, src fs.ObjectInfo, options ...fs.OpenOption) error {
-	return errorReadOnly
+	if !o.fs.depositEnabled() {
+		return errorReadOnly
+	}
+	draft, err := o.fs.openDraft(ctx)
+	if err != nil {
+		return err
+	}
+	if err := o.fs.uploadDraftFile(ctx, draft, o.remote, in); err != nil {
+		return err
+	}
+	o.size = src.Size()
+	o.modTime = src.ModTime(ctx)
+	return nil
 }
 
 // MimeType of an Object if known, "" otherwise
@@ -477,11 +694,36 @@ func (o *Object) MimeType(ctx context.Context) string {
 var commandHelp = []fs.CommandHelp{{
 	Name:  "show-metadata",
 	Short: "Show metadata about the DOI.",
-	Long: `This command returns the JSON representation of the DOI.
+	Long: `This command returns a representation of the DOI's metadata.
 
-    rclone backend show-medatadata doi: 
+    rclone backend show-metadata doi:
+    rclone backend show-metadata doi: -o format=schema-org
 
-It returns a JSON object representing the DOI.
+By default it returns the JSON object as returned by the provider's API.
+The 'format' option normalizes the metadata across providers and
+re-serializes it; supported values are 'raw' (the default), 'schema-org',
+'datacite-json', 'bibtex' and 'citeproc'.
+`,
+	Opts: map[string]string{
+		"format": "The format to render the metadata in: raw, schema-org, datacite-json, bibtex, citeproc.",
+	},
+}, {
+	Name:  "show-citation",
+	Short: "Show a citation for the DOI.",
+	Long: `This command returns a ready-to-use BibTeX citation for the DOI,
+built from its normalized metadata.
+
+    rclone backend show-citation doi:
+`,
+}, {
+	Name:  "doi-cache-purge",
+	Short: "Purge the on-disk listings/metadata/linkset cache for this remote.",
+	Long: `This command removes every cached entry for this remote from the
+on-disk cache (see the 'cache_dir', 'cache_max_age' and 'cache_disabled'
+options), forcing the next listing or metadata request to re-fetch from
+the provider.
+
+    rclone backend doi-cache-purge doi:
 `,
 }, {
 	Name:  "set",
@@ -503,6 +745,17 @@ will default to those currently in use.
 
 It doesn't return anything.
 `,
+}, {
+	Name:  "publish",
+	Short: "Publish a draft record and mint its DOI.",
+	Long: `This command publishes the draft record created in this session by
+previous Put/Update calls, finalizing it and returning the newly minted DOI.
+
+    rclone backend publish doi:
+
+This is only available when 'deposit_token' is configured and the
+provider supports deposit (Zenodo, InvenioRDM).
+`,
 }}
 
 // Command the backend to run a named command
@@ -517,7 +770,16 @@ It doesn't return anything.
 func (f *Fs) Command(ctx context.Context, name string, arg []string, opt map[string]string) (out interface{}, err error) {
 	switch name {
 	case "show-metadata":
-		return f.ShowMetadata(ctx)
+		return f.ShowMetadata(ctx, opt["format"])
+	case "show-citation":
+		return f.ShowCitation(ctx)
+	case "doi-cache-purge":
+		return nil, f.diskCache.purge(f.diskCacheKey())
+	case "publish":
+		if !f.depositEnabled() {
+			return nil, fmt.Errorf("deposit is not enabled: set 'deposit_token' for a provider that supports it")
+		}
+		return f.publishDraft(ctx)
 	case "set":
 		newOpt := f.opt
 		err := configstruct.Set(configmap.Simple(opt), &newOpt)
@@ -540,21 +802,6 @@ func (f *Fs) Command(ctx context.Context, name string, arg []string, opt map[str
 	}
 }
 
-// ShowMetadata returns the metadata associated with the DOI
-func (f *Fs) ShowMetadata(ctx context.Context) (metadata interface{}, err error) {
-	metadataURL := f.endpoint
-	var result any
-	opts := rest.Opts{
-		Method:  "GET",
-		RootURL: metadataURL.String(),
-	}
-	_, err = f.srv.CallJSON(ctx, &opts, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return result, err
-}
-
 // Check the interfaces are satisfied
 var (
 	_ fs.Fs          = (*Fs)(nil)
@@ -19,7 +19,7 @@ import (
 var invenioRecordRegex = regexp.MustCompile(`\/records?\/(.+)`)
 
 // Resolve the main API endpoint for a DOI hosted on an InvenioDRM installation
-func resolveInvenioEndpoint(ctx context.Context, client *http.Client, resolvedURL *url.URL) (provider Provider, endpoint *url.URL, err error) {
+func resolveInvenioEndpoint(ctx context.Context, pacer *fs.Pacer, client *http.Client, resolvedURL *url.URL) (provider Provider, endpoint *url.URL, err error) {
 	fs.Logf(nil, "invenioURL = %s", resolvedURL.String())
 
 	restClient := rest.NewClient(client)
@@ -27,32 +27,28 @@ func resolveInvenioEndpoint(ctx context.Context, client *http.Client, resolvedUR
 		Method:  "GET",
 		RootURL: resolvedURL.String(),
 	}
-	res, err := restClient.Call(ctx, &opts)
+	var res *http.Response
+	err = pacer.Call(func() (bool, error) {
+		res, err = restClient.Call(ctx, &opts)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
 		return "", nil, err
 	}
 
-	// First, attempt to grab the API URL from the headers
-	var linksetURL *url.URL
+	// First, attempt to grab the API URL from the Signposting linkset link
 	links := parseLinkHeader(res.Header.Get("Link"))
-	for _, link := range links {
-		if link.Rel == "linkset" && link.Type == "application/linkset+json" {
-			parsed, err := url.Parse(link.Href)
+	if linksetURLStr, found := findLinksetURL(links); found {
+		linksetURL, parseErr := url.Parse(linksetURLStr)
+		if parseErr == nil {
+			endpoint, err = checkInvenioApiURL(ctx, pacer, restClient, linksetURL)
 			if err == nil {
-				linksetURL = parsed
-				break
+				return Invenio, endpoint, nil
 			}
+			fs.Logf(nil, "using linkset URL failed: %s", err.Error())
 		}
 	}
 
-	if linksetURL != nil {
-		endpoint, err = checkInvenioApiURL(ctx, restClient, linksetURL)
-		if err == nil {
-			return Invenio, endpoint, nil
-		}
-		fs.Logf(nil, "using linkset URL failed: %s", err.Error())
-	}
-
 	// If there is no linkset header, try to grab the record ID from the URL
 	recordID := ""
 	resURL := res.Request.URL
@@ -63,7 +59,7 @@ func resolveInvenioEndpoint(ctx context.Context, client *http.Client, resolvedUR
 		guessedURL := res.Request.URL.ResolveReference(&url.URL{
 			Path: "/api/records/" + recordID,
 		})
-		endpoint, err = checkInvenioApiURL(ctx, restClient, guessedURL)
+		endpoint, err = checkInvenioApiURL(ctx, pacer, restClient, guessedURL)
 		if err == nil {
 			return Invenio, endpoint, nil
 		}
@@ -73,13 +69,17 @@ func resolveInvenioEndpoint(ctx context.Context, client *http.Client, resolvedUR
 	return "", nil, fmt.Errorf("could not resolve the Invenio API endpoint for '%s'", resolvedURL.String())
 }
 
-func checkInvenioApiURL(ctx context.Context, client *rest.Client, resolvedURL *url.URL) (endpoint *url.URL, err error) {
+func checkInvenioApiURL(ctx context.Context, pacer *fs.Pacer, client *rest.Client, resolvedURL *url.URL) (endpoint *url.URL, err error) {
 	opts := rest.Opts{
 		Method:  "GET",
 		RootURL: resolvedURL.String(),
 	}
 	var result api.InvenioRecordResponse
-	_, err = client.CallJSON(ctx, &opts, nil, &result)
+	var res *http.Response
+	err = pacer.Call(func() (bool, error) {
+		res, err = client.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +89,54 @@ func checkInvenioApiURL(ctx context.Context, client *rest.Client, resolvedURL *u
 	return url.Parse(result.Links.Self)
 }
 
+// fetchInvenioMetadata fetches and normalizes the metadata of a record
+// hosted on Zenodo or an InvenioDRM installation
+func (f *Fs) fetchInvenioMetadata(ctx context.Context) (*Metadata, error) {
+	var result api.InvenioRecordResponse
+	var res *http.Response
+	opts := rest.Opts{
+		Method:  "GET",
+		RootURL: f.endpoint.String(),
+	}
+	err := f.pacer.Call(func() (bool, error) {
+		var callErr error
+		res, callErr = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, callErr)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch metadata: %w", err)
+	}
+
+	files, err := f.metadataFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := result.Metadata
+	authors := make([]string, 0, len(metadata.Creators))
+	for _, creator := range metadata.Creators {
+		authors = append(authors, creator.PersonOrOrg.Name)
+	}
+	license := ""
+	if len(metadata.Rights) > 0 {
+		license = metadata.Rights[0].ID
+	}
+	relatedIdentifiers := make([]string, 0, len(metadata.RelatedIdentifiers))
+	for _, related := range metadata.RelatedIdentifiers {
+		relatedIdentifiers = append(relatedIdentifiers, related.Identifier)
+	}
+
+	return &Metadata{
+		Title:              metadata.Title,
+		Authors:            authors,
+		PublicationDate:    metadata.PublicationDate,
+		Version:            metadata.Version,
+		License:            license,
+		RelatedIdentifiers: relatedIdentifiers,
+		Files:              files,
+	}, nil
+}
+
 // Implements Fs.List() for Invenio
 func (f *Fs) listInvenio(ctx context.Context, dir string) (entries fs.DirEntries, err error) {
 	if dir != "" {
@@ -122,12 +170,16 @@ func (f *Fs) listInvevioDoiFiles(ctx context.Context) (entries []*Object, err er
 
 	filesURL := f.endpoint.JoinPath("files")
 	var result api.InvenioFilesResponse
+	var res *http.Response
 	opts := rest.Opts{
 		Method: "GET",
 		Path:   strings.TrimLeft(filesURL.EscapedPath(), "/"),
 	}
 	fs.Logf(f, "filesAPIPath = '%s'", opts.Path)
-	_, err = f.srv.CallJSON(ctx, &opts, nil, &result)
+	err = f.pacer.Call(func() (bool, error) {
+		res, err = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("readDir failed: %w", err)
 	}
@@ -144,7 +196,7 @@ func (f *Fs) listInvevioDoiFiles(ctx context.Context) (entries []*Object, err er
 			size:        file.Size,
 			modTime:     modTime,
 			contentType: file.MimeType,
-			md5:         strings.TrimLeft(file.Checksum, "md5:"),
+			md5:         strings.TrimPrefix(file.Checksum, "md5:"),
 		}
 		entries = append(entries, entry)
 	}
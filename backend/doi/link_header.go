@@ -0,0 +1,55 @@
+// Parsing of RFC 8288 Link headers
+
+package doi
+
+import "strings"
+
+// headerLink is a single link found in a Link HTTP header
+type headerLink struct {
+	Href   string            // the target URI of the link
+	Rel    string            // the relation type of the link
+	Type   string            // the media type of the target, if given
+	Extras map[string]string // any other parameters found on the link
+}
+
+// parseLinkHeader parses the value of a Link HTTP header (RFC 8288) into
+// a list of headerLink values.
+//
+// Example:
+//
+//	<https://zenodo.org/api/records/15063252> ; rel="linkset" ; type="application/linkset+json"
+func parseLinkHeader(header string) (links []headerLink) {
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		segments := strings.Split(part, ";")
+		href := strings.TrimSpace(segments[0])
+		href = strings.TrimPrefix(href, "<")
+		href = strings.TrimSuffix(href, ">")
+		link := headerLink{
+			Href:   href,
+			Extras: map[string]string{},
+		}
+		for _, segment := range segments[1:] {
+			segment = strings.TrimSpace(segment)
+			kv := strings.SplitN(segment, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			key := strings.TrimSpace(kv[0])
+			value := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			switch key {
+			case "rel":
+				link.Rel = value
+			case "type":
+				link.Type = value
+			default:
+				link.Extras[key] = value
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
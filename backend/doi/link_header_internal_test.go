@@ -17,3 +17,10 @@ func TestParseLinkHeader(t *testing.T) {
 	}
 	assert.Contains(t, links, expected)
 }
+
+func TestSignpostingVirtualFileName(t *testing.T) {
+	counts := map[string]int{}
+	assert.Equal(t, ".license", signpostingVirtualFileName("license", counts))
+	assert.Equal(t, ".license-2", signpostingVirtualFileName("license", counts))
+	assert.Equal(t, ".author", signpostingVirtualFileName("author", counts))
+}
@@ -0,0 +1,292 @@
+// Normalization of provider-specific metadata into a common model, and
+// serialization of that model into formats commonly consumed by citation
+// and provenance tooling.
+
+package doi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rclone/rclone/backend/doi/api"
+	"github.com/rclone/rclone/lib/rest"
+)
+
+// Metadata is a normalized, provider-agnostic view of a DOI's descriptive
+// metadata, modeled loosely on the DataCite/Crossref/JSON Feed shapes.
+type Metadata struct {
+	Title              string         `json:"title"`
+	Authors            []string       `json:"authors"`
+	PublicationDate    string         `json:"publicationDate"`
+	Version            string         `json:"version"`
+	License            string         `json:"license"`
+	RelatedIdentifiers []string       `json:"relatedIdentifiers"`
+	Files              []MetadataFile `json:"files"`
+}
+
+// MetadataFile describes a single file as it appears in normalized metadata
+type MetadataFile struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// fetchMetadata normalizes the provider-specific metadata for f's DOI into
+// the common Metadata model, consulting the on-disk cache before making a
+// fresh request
+func (f *Fs) fetchMetadata(ctx context.Context) (*Metadata, error) {
+	var cached Metadata
+	if f.diskCache.get(diskCacheMetadata, f.diskCacheKey(), "", &cached) {
+		return &cached, nil
+	}
+
+	metadata, err := f.fetchProviderMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f.diskCache.put(diskCacheMetadata, f.diskCacheKey(), "", metadata)
+	return metadata, nil
+}
+
+// fetchProviderMetadata dispatches to the provider-specific metadata
+// translator, bypassing the on-disk cache
+func (f *Fs) fetchProviderMetadata(ctx context.Context) (*Metadata, error) {
+	switch f.provider {
+	case Zenodo, Invenio:
+		return f.fetchInvenioMetadata(ctx)
+	case Dataverse:
+		return f.fetchDataverseMetadata(ctx)
+	case DataCite:
+		return f.fetchDataCiteMetadata(ctx)
+	case Crossref:
+		return f.fetchCrossrefMetadata(ctx)
+	case Signposting:
+		return f.fetchSignpostingMetadata(ctx)
+	default:
+		return nil, fmt.Errorf("metadata normalization is not supported for provider %q", f.provider)
+	}
+}
+
+// metadataFiles builds the normalized file list shared by every translator,
+// from the Fs' own (possibly cached) file listing
+func (f *Fs) metadataFiles(ctx context.Context) ([]MetadataFile, error) {
+	entries, err := f.listDoiFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]MetadataFile, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, MetadataFile{
+			Name:     entry.remote,
+			Size:     entry.size,
+			Checksum: entry.md5,
+		})
+	}
+	return files, nil
+}
+
+// renderSchemaOrg renders m as a schema.org Dataset JSON-LD document
+func renderSchemaOrg(m *Metadata) map[string]interface{} {
+	doc := map[string]interface{}{
+		"@context": "https://schema.org",
+		"@type":    "Dataset",
+		"name":     m.Title,
+	}
+	if len(m.Authors) > 0 {
+		creators := make([]map[string]interface{}, 0, len(m.Authors))
+		for _, author := range m.Authors {
+			creators = append(creators, map[string]interface{}{"@type": "Person", "name": author})
+		}
+		doc["creator"] = creators
+	}
+	if m.PublicationDate != "" {
+		doc["datePublished"] = m.PublicationDate
+	}
+	if m.Version != "" {
+		doc["version"] = m.Version
+	}
+	if m.License != "" {
+		doc["license"] = m.License
+	}
+	if len(m.RelatedIdentifiers) > 0 {
+		doc["identifier"] = m.RelatedIdentifiers
+	}
+	if len(m.Files) > 0 {
+		parts := make([]map[string]interface{}, 0, len(m.Files))
+		for _, file := range m.Files {
+			part := map[string]interface{}{"@type": "DataDownload", "name": file.Name}
+			if file.Size > 0 {
+				part["contentSize"] = file.Size
+			}
+			if file.Checksum != "" {
+				part["md5"] = file.Checksum
+			}
+			parts = append(parts, part)
+		}
+		doc["hasPart"] = parts
+	}
+	return doc
+}
+
+// renderDataCiteJSON renders m using the shape of a DataCite JSON:API
+// "attributes" object
+func renderDataCiteJSON(m *Metadata) map[string]interface{} {
+	titles := []map[string]interface{}{}
+	if m.Title != "" {
+		titles = append(titles, map[string]interface{}{"title": m.Title})
+	}
+	creators := make([]map[string]interface{}, 0, len(m.Authors))
+	for _, author := range m.Authors {
+		creators = append(creators, map[string]interface{}{"name": author})
+	}
+	doc := map[string]interface{}{
+		"titles":             titles,
+		"creators":           creators,
+		"version":            m.Version,
+		"relatedIdentifiers": m.RelatedIdentifiers,
+	}
+	if m.PublicationDate != "" {
+		doc["publicationYear"] = m.PublicationDate
+	}
+	if m.License != "" {
+		doc["rightsList"] = []map[string]interface{}{{"rights": m.License}}
+	}
+	return doc
+}
+
+// renderBibtex renders m as a single BibTeX @misc entry
+func renderBibtex(m *Metadata) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@misc{%s,\n", bibtexKey(m))
+	fmt.Fprintf(&b, "  title = {%s},\n", m.Title)
+	if len(m.Authors) > 0 {
+		fmt.Fprintf(&b, "  author = {%s},\n", strings.Join(m.Authors, " and "))
+	}
+	if m.PublicationDate != "" {
+		fmt.Fprintf(&b, "  year = {%s},\n", m.PublicationDate)
+	}
+	if m.Version != "" {
+		fmt.Fprintf(&b, "  note = {Version %s},\n", m.Version)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// bibtexKey derives a short citation key from the first author's surname and
+// the publication year, falling back to "dataset" when neither is known
+func bibtexKey(m *Metadata) string {
+	author := "dataset"
+	if len(m.Authors) > 0 {
+		name := m.Authors[0]
+		// Names given as "Family, Given" keep the surname before the comma
+		if comma := strings.Index(name, ","); comma >= 0 {
+			name = name[:comma]
+		}
+		if fields := strings.Fields(name); len(fields) > 0 {
+			author = strings.ToLower(fields[len(fields)-1])
+		}
+	}
+	year := strings.TrimSpace(strings.Split(m.PublicationDate, "-")[0])
+	if year == "" {
+		return author
+	}
+	return author + year
+}
+
+// renderCiteproc renders m as a CSL-JSON (citeproc) item
+func renderCiteproc(m *Metadata) map[string]interface{} {
+	authors := make([]map[string]interface{}, 0, len(m.Authors))
+	for _, author := range m.Authors {
+		authors = append(authors, map[string]interface{}{"literal": author})
+	}
+	doc := map[string]interface{}{
+		"type":   "dataset",
+		"title":  m.Title,
+		"author": authors,
+	}
+	if m.Version != "" {
+		doc["version"] = m.Version
+	}
+	if year := strings.TrimSpace(strings.Split(m.PublicationDate, "-")[0]); year != "" {
+		doc["issued"] = map[string]interface{}{"date-parts": [][]string{{year}}}
+	}
+	return doc
+}
+
+// showRawMetadata returns the raw provider-specific JSON for f's endpoint,
+// the original behaviour of the `show-metadata` command before normalized
+// formats were added
+func (f *Fs) showRawMetadata(ctx context.Context) (interface{}, error) {
+	var result any
+	var res *http.Response
+	opts := rest.Opts{
+		Method:  "GET",
+		RootURL: f.endpoint.String(),
+	}
+	err := f.pacer.Call(func() (bool, error) {
+		var callErr error
+		res, callErr = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, callErr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ShowMetadata returns the metadata associated with the DOI, serialized in
+// the requested format ("raw", "schema-org", "datacite-json", "bibtex" or
+// "citeproc"; "raw" is the default)
+func (f *Fs) ShowMetadata(ctx context.Context, format string) (interface{}, error) {
+	if format == "" || format == "raw" {
+		return f.showRawMetadata(ctx)
+	}
+	metadata, err := f.fetchMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "schema-org":
+		return renderSchemaOrg(metadata), nil
+	case "datacite-json":
+		return renderDataCiteJSON(metadata), nil
+	case "bibtex":
+		return renderBibtex(metadata), nil
+	case "citeproc":
+		return renderCiteproc(metadata), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q: must be one of raw, schema-org, datacite-json, bibtex, citeproc", format)
+	}
+}
+
+// ShowCitation returns a ready-to-use BibTeX citation for the DOI
+func (f *Fs) ShowCitation(ctx context.Context) (string, error) {
+	metadata, err := f.fetchMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	return renderBibtex(metadata), nil
+}
+
+// listDoiFiles lists the files for f's DOI, delegating to the
+// provider-specific listing used by List/NewObject, regardless of f.root
+func (f *Fs) listDoiFiles(ctx context.Context) ([]*Object, error) {
+	switch f.provider {
+	case Zenodo:
+		return f.listZenodoDoiFiles(ctx)
+	case Invenio:
+		return f.listInvevioDoiFiles(ctx)
+	case Dataverse:
+		return f.listDataverseDoiFiles(ctx)
+	case DataCite:
+		return f.listDataCiteDoiFiles(ctx)
+	case Crossref:
+		return f.listCrossrefDoiFiles(ctx)
+	case Signposting:
+		return f.listSignpostingDoiFiles(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported provider %q", f.provider)
+	}
+}
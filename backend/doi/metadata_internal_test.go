@@ -0,0 +1,23 @@
+package doi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBibtexKey(t *testing.T) {
+	assert.Equal(t, "smith2020", bibtexKey(&Metadata{Authors: []string{"Jane Smith"}, PublicationDate: "2020-01-01"}))
+	assert.Equal(t, "dataset2020", bibtexKey(&Metadata{PublicationDate: "2020-01-01"}))
+	assert.Equal(t, "dataset", bibtexKey(&Metadata{}))
+}
+
+func TestBibtexKeyEmptyAuthor(t *testing.T) {
+	// An empty author string is reachable via Signposting's rel="author"
+	// links, which carry no guaranteed non-empty href; bibtexKey must not
+	// panic on strings.Fields("") returning an empty slice.
+	assert.NotPanics(t, func() {
+		bibtexKey(&Metadata{Authors: []string{""}, PublicationDate: "2020-01-01"})
+	})
+	assert.Equal(t, "dataset2020", bibtexKey(&Metadata{Authors: []string{""}, PublicationDate: "2020-01-01"}))
+}
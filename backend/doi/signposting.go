@@ -0,0 +1,386 @@
+// Implementation of the cross-publisher Signposting convention
+//
+// Reference: https://signposting.org/conventions/
+
+package doi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rclone/rclone/backend/doi/api"
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/lib/rest"
+)
+
+// signpostingItemRels are the Link relations that identify a downloadable file
+var signpostingItemRels = map[string]bool{
+	"item":       true,
+	"collection": true,
+}
+
+// signpostingVirtualFileRels are the Link relations that describe the
+// dataset itself rather than one of its files; these are exposed as
+// virtual files (e.g. ".cite-as") rather than filtered out, so that tools
+// which only understand a plain file listing can still reach them
+var signpostingVirtualFileRels = map[string]string{
+	"cite-as":     ".cite-as",
+	"describedby": ".describedby",
+	"license":     ".license",
+	"author":      ".author",
+}
+
+// signpostingVirtualFileName returns the remote name a virtual file for rel
+// should be exposed as, disambiguating with a numeric suffix if more than
+// one link shares the same relation
+func signpostingVirtualFileName(rel string, counts map[string]int) string {
+	name := signpostingVirtualFileRels[rel]
+	counts[rel]++
+	if counts[rel] == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, counts[rel])
+}
+
+// Resolve a DOI by following the cross-publisher Signposting convention:
+// fetch the resolved landing page and look for typed links advertising
+// downloadable `item`s, via either of the two discovery mechanisms the
+// convention defines: the page's `Link` header (possibly pointing at a
+// `linkset+json` document), or content negotiation for
+// `application/linkset+json` directly on the page itself.
+//
+// This doesn't depend on recognising the hostname or provider at all, so it
+// is tried before the InvenioRDM, DataCite and Crossref resolvers; only the
+// Dataverse and Zenodo hostname matches take precedence over it.
+func resolveSignpostingEndpoint(ctx context.Context, pacer *fs.Pacer, client *http.Client, resolvedURL *url.URL) (provider Provider, endpoint *url.URL, err error) {
+	restClient := rest.NewClient(client)
+	links, err := fetchLinkset(ctx, pacer, restClient, resolvedURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, link := range links {
+		if signpostingItemRels[link.Rel] {
+			return Signposting, resolvedURL, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("no Signposting links found at '%s'", resolvedURL.String())
+}
+
+// findLinksetURL returns the href of the first `rel="linkset"` link of type
+// `application/linkset+json`, if any
+func findLinksetURL(links []headerLink) (linksetURL string, found bool) {
+	for _, link := range links {
+		if link.Rel == "linkset" && link.Type == "application/linkset+json" {
+			return link.Href, true
+		}
+	}
+	return "", false
+}
+
+// fetchPageLinks fetches pageURL and returns the typed links found in its
+// `Link` header
+func fetchPageLinks(ctx context.Context, pacer *fs.Pacer, client *rest.Client, pageURL *url.URL) (links []headerLink, err error) {
+	opts := rest.Opts{
+		Method:  "GET",
+		RootURL: pageURL.String(),
+	}
+	var res *http.Response
+	err = pacer.Call(func() (bool, error) {
+		res, err = client.Call(ctx, &opts)
+		return shouldRetry(res, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer fs.CheckClose(res.Body, &err)
+	return parseLinkHeader(res.Header.Get("Link")), nil
+}
+
+// linksetAcceptHeader is the media type requested when content-negotiating
+// for a linkset document directly on a resource, per
+// https://signposting.org/conventions/#linksetmediatype
+const linksetAcceptHeader = "application/linkset+json"
+
+// fetchLinkset returns the full set of typed links describing resolvedURL,
+// trying both discovery mechanisms the Signposting convention defines: the
+// `Link` header (plus the `linkset+json` document it may point at), and,
+// when no linkset document could be fetched that way, content negotiation
+// for `application/linkset+json` directly on resolvedURL, whose links are
+// added to any the `Link` header carried.
+func fetchLinkset(ctx context.Context, pacer *fs.Pacer, client *rest.Client, resolvedURL *url.URL) (links []headerLink, err error) {
+	links, err = fetchPageLinks(ctx, pacer, client, resolvedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if linksetURL, found := findLinksetURL(links); found {
+		linksetLinks, linksetErr := fetchLinksetDocument(ctx, pacer, client, linksetURL)
+		if linksetErr != nil {
+			fs.Logf(nil, "could not fetch linkset document '%s': %s", linksetURL, linksetErr.Error())
+		} else {
+			return append(links, linksetLinks...), nil
+		}
+	}
+
+	// No usable `Link` header, or it didn't point at a linkset document:
+	// some repositories (e.g. ones that only support content negotiation)
+	// expose the linkset by returning it directly when asked for
+	// `application/linkset+json` on the resource itself.
+	negotiatedLinks, negotiatedErr := fetchLinksetDocument(ctx, pacer, client, resolvedURL.String())
+	if negotiatedErr != nil {
+		fs.Logf(nil, "could not fetch linkset via content negotiation on '%s': %s", resolvedURL.String(), negotiatedErr.Error())
+		return links, nil
+	}
+	return append(links, negotiatedLinks...), nil
+}
+
+// fetchLinksetDocument fetches linksetURL, content-negotiating for
+// `application/linkset+json`, and parses the resulting linkset document.
+// This both serves the case where linksetURL is a dedicated linkset
+// document (as referenced by a `Link` header) and the case where it's the
+// resolved URL itself, responding to content negotiation directly.
+func fetchLinksetDocument(ctx context.Context, pacer *fs.Pacer, client *rest.Client, linksetURL string) (links []headerLink, err error) {
+	opts := rest.Opts{
+		Method:       "GET",
+		RootURL:      linksetURL,
+		ExtraHeaders: map[string]string{"Accept": linksetAcceptHeader},
+	}
+	var doc api.LinksetDocument
+	var res *http.Response
+	err = pacer.Call(func() (bool, error) {
+		res, err = client.CallJSON(ctx, &opts, nil, &doc)
+		return shouldRetry(res, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, set := range doc.Linkset {
+		for _, item := range set.Item {
+			links = append(links, linksetLinkToHeaderLink(item, "item"))
+		}
+		for _, item := range set.DescribedBy {
+			links = append(links, linksetLinkToHeaderLink(item, "describedby"))
+		}
+		for _, item := range set.CiteAs {
+			links = append(links, linksetLinkToHeaderLink(item, "cite-as"))
+		}
+		for _, item := range set.Collection {
+			links = append(links, linksetLinkToHeaderLink(item, "collection"))
+		}
+		for _, item := range set.License {
+			links = append(links, linksetLinkToHeaderLink(item, "license"))
+		}
+		for _, item := range set.Author {
+			links = append(links, linksetLinkToHeaderLink(item, "author"))
+		}
+	}
+	return links, nil
+}
+
+func linksetLinkToHeaderLink(link api.LinksetLink, rel string) headerLink {
+	extras := map[string]string{}
+	if link.Length > 0 {
+		extras["length"] = strconv.FormatInt(link.Length, 10)
+	}
+	if link.Hreflang != "" {
+		extras["hreflang"] = link.Hreflang
+	}
+	return headerLink{
+		Href:   link.Href,
+		Rel:    rel,
+		Type:   link.Type,
+		Extras: extras,
+	}
+}
+
+// fetchLinksetCached returns the Signposting linkset describing f's
+// endpoint, consulting the on-disk cache before making a fresh request;
+// this is what lets the linkset survive across rclone invocations
+// alongside the listings/metadata caches
+func (f *Fs) fetchLinksetCached(ctx context.Context) ([]headerLink, error) {
+	var cached []headerLink
+	if f.diskCache.get(diskCacheLinkset, f.diskCacheKey(), "", &cached) {
+		return cached, nil
+	}
+
+	links, err := fetchLinkset(ctx, f.pacer, f.srv, f.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	f.diskCache.put(diskCacheLinkset, f.diskCacheKey(), "", links)
+	return links, nil
+}
+
+// md5FromDigest extracts an MD5 checksum from an RFC 3230 `digest` link
+// attribute (e.g. `digest="md5=oZp5/TwdTJwzYsOJo0EBgA=="`), returning it
+// hex-encoded to match hash.MD5's usual representation.
+func md5FromDigest(digest string) string {
+	for _, part := range strings.Split(digest, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 || !strings.EqualFold(kv[0], "md5") {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(kv[1])
+		if err != nil {
+			continue
+		}
+		return hex.EncodeToString(decoded)
+	}
+	return ""
+}
+
+// Implements Fs.List() for Signposting
+func (f *Fs) listSignposting(ctx context.Context, dir string) (entries fs.DirEntries, err error) {
+	if dir != "" {
+		return nil, fs.ErrorDirNotFound
+	}
+
+	fileEntries, err := f.listSignpostingDoiFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %q: %w", dir, err)
+	}
+	for _, entry := range fileEntries {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// List the files advertised via Signposting `item`/`collection` links,
+// plus a virtual file for each `cite-as`/`describedby`/`license`/`author`
+// link found (see signpostingVirtualFileRels)
+func (f *Fs) listSignpostingDoiFiles(ctx context.Context) (entries []*Object, err error) {
+	// Use the cache if populated
+	cachedEntries, found := f.cache.GetMaybe("files")
+	if found {
+		parsedEntries, ok := cachedEntries.([]Object)
+		if ok {
+			for _, entry := range parsedEntries {
+				newEntry := entry
+				entries = append(entries, &newEntry)
+			}
+			return entries, nil
+		}
+	}
+
+	links, err := f.fetchLinksetCached(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("readDir failed: %w", err)
+	}
+
+	virtualFileCounts := map[string]int{}
+	for _, link := range links {
+		var remote string
+		switch {
+		case signpostingItemRels[link.Rel]:
+			parsed, parseErr := url.Parse(link.Href)
+			if parseErr != nil {
+				fs.Logf(f, "could not parse link URL '%s': %v", link.Href, parseErr)
+				continue
+			}
+			remote = path.Base(parsed.Path)
+		case signpostingVirtualFileRels[link.Rel] != "":
+			remote = signpostingVirtualFileName(link.Rel, virtualFileCounts)
+		default:
+			continue
+		}
+		entry := &Object{
+			fs:          f,
+			remote:      remote,
+			contentURL:  link.Href,
+			size:        f.signpostingItemSize(ctx, link),
+			modTime:     timeUnset,
+			contentType: link.Type,
+		}
+		if digest, ok := link.Extras["digest"]; ok {
+			entry.md5 = md5FromDigest(digest)
+		}
+		entries = append(entries, entry)
+	}
+
+	// Populate the cache
+	cacheEntries := []Object{}
+	for _, entry := range entries {
+		cacheEntries = append(cacheEntries, *entry)
+	}
+	f.cache.Put("files", cacheEntries)
+	return entries, nil
+}
+
+// fetchSignpostingMetadata normalizes the typed links advertised via
+// Signposting into the common Metadata model. Signposting doesn't convey a
+// title, so Authors/License/RelatedIdentifiers are populated from the
+// `author`/`license`/`cite-as` links (as-is, since Signposting gives no
+// separate display name for them) and Files only includes `item`/
+// `collection` links, not the virtual metadata files.
+func (f *Fs) fetchSignpostingMetadata(ctx context.Context) (*Metadata, error) {
+	links, err := f.fetchLinksetCached(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch metadata: %w", err)
+	}
+
+	metadata := &Metadata{}
+	for _, link := range links {
+		switch link.Rel {
+		case "author":
+			metadata.Authors = append(metadata.Authors, link.Href)
+		case "license":
+			if metadata.License == "" {
+				metadata.License = link.Href
+			}
+		case "cite-as":
+			metadata.RelatedIdentifiers = append(metadata.RelatedIdentifiers, link.Href)
+		case "item", "collection":
+			parsed, parseErr := url.Parse(link.Href)
+			if parseErr != nil {
+				fs.Logf(f, "could not parse link URL '%s': %v", link.Href, parseErr)
+				continue
+			}
+			file := MetadataFile{
+				Name: path.Base(parsed.Path),
+				Size: f.signpostingItemSize(ctx, link),
+			}
+			if digest, ok := link.Extras["digest"]; ok {
+				file.Checksum = md5FromDigest(digest)
+			}
+			metadata.Files = append(metadata.Files, file)
+		}
+	}
+	return metadata, nil
+}
+
+// signpostingItemSize returns the size advertised by a `length` link
+// attribute, falling back to a HEAD request's Content-Length header
+func (f *Fs) signpostingItemSize(ctx context.Context, link headerLink) int64 {
+	if length, ok := link.Extras["length"]; ok {
+		if parsed, err := strconv.ParseInt(length, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	opts := rest.Opts{
+		Method:  "HEAD",
+		RootURL: link.Href,
+	}
+	var res *http.Response
+	err := f.pacer.Call(func() (bool, error) {
+		var callErr error
+		res, callErr = f.srv.Call(ctx, &opts)
+		return shouldRetry(res, callErr)
+	})
+	if err != nil {
+		return -1
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	return res.ContentLength
+}
@@ -0,0 +1,79 @@
+// Integrity verification for downloaded objects
+
+package doi
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// isPartialRead reports whether options request a range or seek, in which
+// case the full-object MD5/size verification below doesn't apply
+func isPartialRead(options []fs.OpenOption) bool {
+	for _, opt := range options {
+		switch opt.(type) {
+		case *fs.RangeOption, *fs.SeekOption:
+			return true
+		}
+	}
+	return false
+}
+
+// verifiedReadCloser wraps a ReadCloser, hashing its content as it is read
+// and verifying the digest and size against the expected values once the
+// stream is exhausted
+type verifiedReadCloser struct {
+	io.ReadCloser
+	hasher interface {
+		io.Writer
+		Sum(b []byte) []byte
+	}
+	wantMD5  string
+	wantSize int64
+	read     int64
+}
+
+// newVerifiedReadCloser wraps rc so that, once fully read, its content is
+// checked against wantMD5 (if non-empty) and wantSize (if non-negative)
+func newVerifiedReadCloser(rc io.ReadCloser, wantMD5 string, wantSize int64) io.ReadCloser {
+	return &verifiedReadCloser{
+		ReadCloser: rc,
+		hasher:     md5.New(),
+		wantMD5:    wantMD5,
+		wantSize:   wantSize,
+	}
+}
+
+// Read implements io.Reader, hashing data as it passes through and
+// verifying it once the underlying reader reports EOF
+func (v *verifiedReadCloser) Read(p []byte) (n int, err error) {
+	n, err = v.ReadCloser.Read(p)
+	if n > 0 {
+		v.read += int64(n)
+		_, _ = v.hasher.Write(p[:n])
+	}
+	if err == io.EOF {
+		if verifyErr := v.verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+// verify compares the accumulated size and MD5 against what was expected
+func (v *verifiedReadCloser) verify() error {
+	if v.wantSize >= 0 && v.read != v.wantSize {
+		return fmt.Errorf("corrupted on transfer: sizes differ %d vs %d", v.wantSize, v.read)
+	}
+	if v.wantMD5 != "" {
+		gotMD5 := hex.EncodeToString(v.hasher.Sum(nil))
+		if gotMD5 != v.wantMD5 {
+			return fmt.Errorf("corrupted on transfer: MD5 hashes differ %q vs %q", v.wantMD5, gotMD5)
+		}
+	}
+	return nil
+}
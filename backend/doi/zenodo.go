@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/rclone/rclone/backend/doi/api"
 	"github.com/rclone/rclone/fs"
@@ -17,7 +19,7 @@ import (
 var zenodoRecordRegex = regexp.MustCompile(`zenodo[.](.+)`)
 
 // Resolve the main API endpoint for a DOI hosted on Zenodo
-func resolveZenodoEndpoint(ctx context.Context, client *http.Client, resolvedURL *url.URL, doi string) (provider Provider, endpoint *url.URL, err error) {
+func resolveZenodoEndpoint(ctx context.Context, pacer *fs.Pacer, client *http.Client, resolvedURL *url.URL, doi string) (provider Provider, endpoint *url.URL, err error) {
 	fs.Logf(nil, "zenodoURL = %s", resolvedURL.String())
 
 	match := zenodoRecordRegex.FindStringSubmatch(doi)
@@ -30,11 +32,15 @@ func resolveZenodoEndpoint(ctx context.Context, client *http.Client, resolvedURL
 
 	restClient := rest.NewClient(client)
 	var result api.InvenioRecordResponse
+	var res *http.Response
 	opts := rest.Opts{
 		Method:  "GET",
 		RootURL: endpointURL.String(),
 	}
-	_, err = restClient.CallJSON(ctx, &opts, nil, &result)
+	err = pacer.Call(func() (bool, error) {
+		res, err = restClient.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
 	if err != nil {
 		return "", nil, err
 	}
@@ -47,3 +53,118 @@ func resolveZenodoEndpoint(ctx context.Context, client *http.Client, resolvedURL
 	fs.Logf(nil, "endpointURL = %s", endpointURL.String())
 	return Zenodo, endpointURL, nil
 }
+
+// currentZenodoVersion cheaply probes the record's current version
+// (metadata.version) by re-fetching the record itself, without its files,
+// so the on-disk listings cache can be checked for staleness without
+// paying for the heavier files listing it exists to avoid. Returns "" if
+// the probe itself fails, in which case the cache falls back to being
+// governed by its TTL alone.
+func (f *Fs) currentZenodoVersion(ctx context.Context) string {
+	var result api.InvenioRecordResponse
+	var res *http.Response
+	opts := rest.Opts{
+		Method: "GET",
+		Path:   strings.TrimLeft(f.endpoint.EscapedPath(), "/"),
+	}
+	err := f.pacer.Call(func() (bool, error) {
+		var callErr error
+		res, callErr = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, callErr)
+	})
+	if err != nil {
+		fs.Logf(f, "could not probe current DOI version: %v", err)
+		return ""
+	}
+	return result.Metadata.Version
+}
+
+// Implements Fs.List() for Zenodo
+func (f *Fs) listZenodo(ctx context.Context, dir string) (entries fs.DirEntries, err error) {
+	if dir != "" {
+		return nil, fs.ErrorDirNotFound
+	}
+
+	fileEntries, err := f.listZenodoDoiFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %q: %w", dir, err)
+	}
+	for _, entry := range fileEntries {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// List the files contained in the DOI
+func (f *Fs) listZenodoDoiFiles(ctx context.Context) (entries []*Object, err error) {
+	// Use the in-process cache if populated
+	cachedEntries, found := f.cache.GetMaybe("files")
+	if found {
+		parsedEntries, ok := cachedEntries.([]Object)
+		if ok {
+			for _, entry := range parsedEntries {
+				newEntry := entry
+				entries = append(entries, &newEntry)
+			}
+			return entries, nil
+		}
+	}
+
+	// Fall back to the on-disk cache, which survives across invocations.
+	// Probe the record's current version first: it's a single lightweight
+	// GET of the record itself (no files), so it's cheap enough to afford
+	// before trusting a cached listing, and lets a change to the record
+	// invalidate the cache immediately instead of waiting out its TTL.
+	version := f.currentZenodoVersion(ctx)
+	var diskCachedEntries []diskCacheObject
+	if f.diskCache.get(diskCacheListings, f.diskCacheKey(), version, &diskCachedEntries) {
+		entries = fromDiskCacheObjects(f, diskCachedEntries)
+		cacheEntries := []Object{}
+		for _, entry := range entries {
+			cacheEntries = append(cacheEntries, *entry)
+		}
+		f.cache.Put("files", cacheEntries)
+		return entries, nil
+	}
+
+	filesURL := f.endpoint.JoinPath("files")
+	var result api.InvenioFilesResponse
+	var res *http.Response
+	opts := rest.Opts{
+		Method: "GET",
+		Path:   strings.TrimLeft(filesURL.EscapedPath(), "/"),
+	}
+	fs.Logf(f, "filesAPIPath = '%s'", opts.Path)
+	err = f.pacer.Call(func() (bool, error) {
+		res, err = f.srv.CallJSON(ctx, &opts, nil, &result)
+		return shouldRetry(res, err)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("readDir failed: %w", err)
+	}
+	for _, file := range result.Entries {
+		modTime, modTimeErr := time.Parse(time.RFC3339, file.Updated)
+		if modTimeErr != nil {
+			fs.Logf(f, "error: could not parse last update time %v", modTimeErr)
+			modTime = timeUnset
+		}
+		entry := &Object{
+			fs:          f,
+			remote:      file.Key,
+			contentURL:  file.Links.Content,
+			size:        file.Size,
+			modTime:     modTime,
+			contentType: file.MimeType,
+			md5:         strings.TrimPrefix(file.Checksum, "md5:"),
+		}
+		entries = append(entries, entry)
+	}
+	// Populate the in-process and on-disk caches
+	cacheEntries := []Object{}
+	for _, entry := range entries {
+		cacheEntries = append(cacheEntries, *entry)
+	}
+	f.cache.Put("files", cacheEntries)
+	f.diskCache.put(diskCacheListings, f.diskCacheKey(), version, toDiskCacheObjects(entries))
+	return entries, nil
+}